@@ -4,31 +4,84 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/sathish316/maverickv2/faultsim/pkg"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		runValidate(os.Args[2:])
+		return
+	}
+
 	scenarioPath := flag.String("scenario", "", "Path to scenario YAML file or glob pattern (e.g., scenarios/*.yml)")
+	parallel := flag.Int("parallel", 1, "Number of scenarios to run concurrently when -scenario matches multiple files")
+	report := flag.String("report", "", "Path to write a JUnit XML report")
 	flag.Parse()
 
 	if *scenarioPath == "" {
 		fmt.Println("Error: -scenario flag is required")
 		fmt.Println("\nUsage:")
-		fmt.Println("  Run single scenario: faultsim -scenario scenarios/beer-redis-failure.yml")
-		fmt.Println("  Run all scenarios:   faultsim -scenario 'scenarios/*.yml'")
+		fmt.Println("  Run single scenario:    faultsim -scenario scenarios/beer-redis-failure.yml")
+		fmt.Println("  Run all scenarios:      faultsim -scenario 'scenarios/*.yml' -parallel 4 -report junit.xml")
+		fmt.Println("  Validate only (no run): faultsim validate -scenario 'scenarios/*.yml'")
 		os.Exit(1)
 	}
 
 	// Check if pattern contains wildcards
 	if hasWildcard(*scenarioPath) {
-		runMultipleScenarios(*scenarioPath)
+		runMultipleScenarios(*scenarioPath, *parallel, *report)
 	} else {
-		runSingleScenario(*scenarioPath)
+		runSingleScenario(*scenarioPath, *report)
+	}
+}
+
+// runValidate implements the `faultsim validate` subcommand: it loads every
+// matching scenario (running the same schema/param/interpolation checks
+// LoadScenario always does) without executing a single step, so CI can gate
+// PRs before any script runs.
+func runValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	scenarioPath := fs.String("scenario", "", "Path to scenario YAML file or glob pattern to validate (e.g., scenarios/*.yml)")
+	fs.Parse(args)
+
+	if *scenarioPath == "" {
+		fmt.Println("Error: -scenario flag is required")
+		os.Exit(1)
+	}
+
+	paths := []string{*scenarioPath}
+	if hasWildcard(*scenarioPath) {
+		matches, err := filepath.Glob(*scenarioPath)
+		if err != nil || len(matches) == 0 {
+			fmt.Printf("Error: no scenarios matched pattern %s\n", *scenarioPath)
+			os.Exit(1)
+		}
+		paths = matches
+	}
+
+	failed := 0
+	for _, path := range paths {
+		if _, err := pkg.LoadScenario(path); err != nil {
+			fmt.Printf("❌ %s: %v\n", path, err)
+			failed++
+		} else {
+			fmt.Printf("✅ %s\n", path)
+		}
+	}
+
+	fmt.Printf("\n%d/%d scenarios valid\n", len(paths)-failed, len(paths))
+	if failed > 0 {
+		os.Exit(1)
 	}
 }
 
-func runSingleScenario(path string) {
+func runSingleScenario(path string, reportPath string) {
 	scenario, err := pkg.LoadScenario(path)
 	if err != nil {
 		fmt.Printf("Error loading scenario: %v\n", err)
@@ -36,47 +89,184 @@ func runSingleScenario(path string) {
 	}
 
 	executor := pkg.NewExecutor(scenario)
-	if err := executor.Execute(); err != nil {
-		fmt.Printf("\n❌ Scenario failed: %v\n", err)
+	stopSignalHandler := installSignalHandler(executor)
+	defer stopSignalHandler()
+
+	start := time.Now()
+	runErr := executor.Execute()
+
+	if reportPath != "" {
+		result := pkg.ScenarioResult{
+			Name:      scenario.ScenarioName,
+			Passed:    runErr == nil,
+			Error:     runErr,
+			Duration:  time.Since(start),
+			LogOutput: executor.Logs(),
+		}
+		if err := pkg.WriteJUnitReport(reportPath, []pkg.ScenarioResult{result}); err != nil {
+			fmt.Printf("Warning: failed to write JUnit report: %v\n", err)
+		} else {
+			fmt.Printf("JUnit report written to %s\n", reportPath)
+		}
+	}
+
+	if runErr != nil {
+		fmt.Printf("\n❌ Scenario failed: %v\n", runErr)
 		os.Exit(1)
 	}
 
 	fmt.Println("✅ Scenario completed successfully")
 }
 
-func runMultipleScenarios(pattern string) {
+func runMultipleScenarios(pattern string, parallel int, reportPath string) {
 	scenarios, err := pkg.LoadScenarios(pattern)
 	if err != nil {
 		fmt.Printf("Error loading scenarios: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("\n=== Running %d scenarios ===\n\n", len(scenarios))
+	if parallel < 1 {
+		parallel = 1
+	}
 
-	passed := 0
-	failed := 0
+	fmt.Printf("\n=== Running %d scenarios (parallel=%d) ===\n\n", len(scenarios), parallel)
+
+	results := make([]pkg.ScenarioResult, len(scenarios))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	var executorsMu sync.Mutex
+	var executors []*pkg.Executor
+	stopSignalHandler := installSignalHandlerForAll(&executorsMu, &executors)
+	defer stopSignalHandler()
 
 	for i, scenario := range scenarios {
-		fmt.Printf("[%d/%d] ", i+1, len(scenarios))
+		wg.Add(1)
+		sem <- struct{}{}
 
-		executor := pkg.NewExecutor(scenario)
-		if err := executor.Execute(); err != nil {
-			fmt.Printf("❌ Failed: %v\n\n", err)
-			failed++
-		} else {
-			fmt.Printf("✅ Passed\n\n")
+		go func(i int, scenario *pkg.Scenario) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			executor := pkg.NewExecutor(scenario)
+
+			executorsMu.Lock()
+			executors = append(executors, executor)
+			executorsMu.Unlock()
+
+			runErr := executor.Execute()
+			results[i] = pkg.ScenarioResult{
+				Name:      scenario.ScenarioName,
+				Passed:    runErr == nil,
+				Error:     runErr,
+				Duration:  time.Since(start),
+				LogOutput: executor.Logs(),
+			}
+
+			if runErr != nil {
+				fmt.Printf("[%s] ❌ Failed: %v\n", scenario.ScenarioName, runErr)
+			} else {
+				fmt.Printf("[%s] ✅ Passed\n", scenario.ScenarioName)
+			}
+		}(i, scenario)
+	}
+
+	wg.Wait()
+
+	passed := 0
+	failed := 0
+	for _, r := range results {
+		if r.Passed {
 			passed++
+		} else {
+			failed++
 		}
 	}
 
 	fmt.Printf("\n=== Summary ===\n")
 	fmt.Printf("Total: %d | Passed: %d | Failed: %d\n", len(scenarios), passed, failed)
 
+	if reportPath != "" {
+		if err := pkg.WriteJUnitReport(reportPath, results); err != nil {
+			fmt.Printf("Warning: failed to write JUnit report: %v\n", err)
+		} else {
+			fmt.Printf("JUnit report written to %s\n", reportPath)
+		}
+	}
+
 	if failed > 0 {
 		os.Exit(1)
 	}
 }
 
+// installSignalHandler reaps an executor's background process tree on
+// Ctrl-C, so aborted runs don't leave traffic generators or fault injectors
+// running after faultsim exits. The returned func stops listening once the
+// scenario it guards has finished normally.
+func installSignalHandler(executor *pkg.Executor) func() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigCh:
+			fmt.Println("\nReceived interrupt, tearing down background processes...")
+			executor.TeardownAll()
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		signal.Stop(sigCh)
+	}
+}
+
+// installSignalHandlerForAll is installSignalHandler's multi-scenario
+// counterpart. Under -parallel, each scenario's goroutine runs its own
+// executor, but a single Ctrl-C is delivered to the whole process - so
+// instead of each goroutine racing to os.Exit on its own (which could kill
+// the process while a sibling scenario's TeardownAll is still mid-grace-
+// period), this installs one process-wide handler that tears down every
+// executor registered so far and waits for them all before exiting once.
+func installSignalHandlerForAll(mu *sync.Mutex, executors *[]*pkg.Executor) func() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigCh:
+			fmt.Println("\nReceived interrupt, tearing down background processes...")
+
+			mu.Lock()
+			active := append([]*pkg.Executor(nil), (*executors)...)
+			mu.Unlock()
+
+			var teardownWg sync.WaitGroup
+			for _, executor := range active {
+				teardownWg.Add(1)
+				go func(e *pkg.Executor) {
+					defer teardownWg.Done()
+					e.TeardownAll()
+				}(executor)
+			}
+			teardownWg.Wait()
+
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		signal.Stop(sigCh)
+	}
+}
+
 func hasWildcard(path string) bool {
 	for _, char := range path {
 		if char == '*' || char == '?' || char == '[' {
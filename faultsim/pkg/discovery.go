@@ -0,0 +1,132 @@
+package pkg
+
+import (
+	"fmt"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+// ServiceInstance is a single resolved instance of a discovered service.
+type ServiceInstance struct {
+	Host string
+	Port int
+}
+
+// DiscoveryBackend resolves fault targets dynamically instead of relying on
+// hosts hardcoded into scenario scripts.
+type DiscoveryBackend interface {
+	// Discover returns the instances of `service` matching tags/health
+	// filters, blocking until the backend's change index advances past
+	// lastIndex (pass 0 for an immediate, non-blocking first call). It
+	// returns the new index so callers can re-poll for further changes.
+	Discover(service string, tags []string, healthyOnly bool, lastIndex uint64) ([]ServiceInstance, uint64, error)
+}
+
+// newDiscoveryBackend resolves a DiscoveryBackend by name, as declared in a
+// fault step's `discovery.backend` field.
+func newDiscoveryBackend(name string) (DiscoveryBackend, error) {
+	switch name {
+	case "", "consul":
+		return NewConsulDiscovery()
+	default:
+		return nil, fmt.Errorf("unknown discovery backend: %s", name)
+	}
+}
+
+// ConsulDiscovery resolves service instances via Consul's health API.
+type ConsulDiscovery struct {
+	client *consulapi.Client
+}
+
+// NewConsulDiscovery creates a ConsulDiscovery using the default Consul
+// client configuration (CONSUL_HTTP_ADDR, CONSUL_HTTP_TOKEN, etc.).
+func NewConsulDiscovery() (*ConsulDiscovery, error) {
+	client, err := consulapi.NewClient(consulapi.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consul client: %w", err)
+	}
+	return &ConsulDiscovery{client: client}, nil
+}
+
+// Discover performs a Consul blocking health query for `service`, filtered
+// by tags and (optionally) passing checks only, waiting on lastIndex so the
+// query only returns once the set of instances has actually changed.
+func (c *ConsulDiscovery) Discover(service string, tags []string, healthyOnly bool, lastIndex uint64) ([]ServiceInstance, uint64, error) {
+	opts := &consulapi.QueryOptions{WaitIndex: lastIndex}
+
+	var entries []*consulapi.ServiceEntry
+	var meta *consulapi.QueryMeta
+	var err error
+
+	if len(tags) > 0 {
+		entries, meta, err = c.client.Health().ServiceMultipleTags(service, tags, healthyOnly, opts)
+	} else {
+		entries, meta, err = c.client.Health().Service(service, "", healthyOnly, opts)
+	}
+	if err != nil {
+		return nil, lastIndex, fmt.Errorf("consul health query for %q failed: %w", service, err)
+	}
+
+	instances := make([]ServiceInstance, 0, len(entries))
+	for _, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		instances = append(instances, ServiceInstance{Host: host, Port: e.Service.Port})
+	}
+
+	return instances, meta.LastIndex, nil
+}
+
+// watchDiscovery repeatedly re-issues blocking Discover calls against
+// backend starting from startIndex, invoking onChange with any instance
+// newly present since initial (or since the previous onChange call), so a
+// scenario can adapt if instances come and go mid-run. It runs until stop is
+// closed.
+func watchDiscovery(backend DiscoveryBackend, service string, tags []string, healthyOnly bool, startIndex uint64, initial []ServiceInstance, stop <-chan struct{}, onChange func([]ServiceInstance)) {
+	lastIndex := startIndex
+	seen := make(map[string]bool, len(initial))
+	for _, inst := range initial {
+		seen[instanceKey(inst)] = true
+	}
+
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		instances, index, err := backend.Discover(service, tags, healthyOnly, lastIndex)
+		if err != nil {
+			fmt.Printf("  [discovery] query for %q failed: %v\n", service, err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		if index == lastIndex {
+			continue
+		}
+		lastIndex = index
+
+		var fresh []ServiceInstance
+		for _, inst := range instances {
+			key := instanceKey(inst)
+			if !seen[key] {
+				seen[key] = true
+				fresh = append(fresh, inst)
+			}
+		}
+		if len(fresh) > 0 {
+			onChange(fresh)
+		}
+	}
+}
+
+// instanceKey uniquely identifies a ServiceInstance for churn-detection
+// purposes.
+func instanceKey(instance ServiceInstance) string {
+	return fmt.Sprintf("%s:%d", instance.Host, instance.Port)
+}
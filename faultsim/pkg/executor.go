@@ -3,118 +3,459 @@ package pkg
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 )
 
 // Executor handles execution of scenario steps
 type Executor struct {
 	scenario *Scenario
+	observer *Observer
+	logger   Logger
+	registry *ProcessRegistry
+	runDir   string
+
+	scriptCallCounts map[string]int
+	lastPID          int
+	lastExitCode     int
+
+	discoveryStop chan struct{}
+	discoveryOnce sync.Once
 }
 
 // NewExecutor creates a new step executor
 func NewExecutor(scenario *Scenario) *Executor {
-	return &Executor{scenario: scenario}
+	var endpoints []PrometheusEndpoint
+	if scenario.Observability != nil {
+		endpoints = scenario.Observability.Endpoints
+	}
+
+	runDir := filepath.Join("runs", sanitizeForPath(scenario.ScenarioName), time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		fmt.Printf("Warning: failed to create run directory %s: %v\n", runDir, err)
+	}
+
+	eventsPath := filepath.Join(runDir, "events.log")
+	eventsFile, err := os.Create(eventsPath)
+	var eventsWriter io.Writer = os.Stdout
+	if err != nil {
+		fmt.Printf("Warning: failed to create events log %s: %v\n", eventsPath, err)
+	} else {
+		eventsWriter = eventsFile
+	}
+
+	return &Executor{
+		scenario:         scenario,
+		observer:         NewObserver(endpoints, runDir),
+		logger:           NewLogrusLogger(eventsWriter),
+		registry:         NewProcessRegistry(),
+		runDir:           runDir,
+		scriptCallCounts: make(map[string]int),
+		discoveryStop:    make(chan struct{}),
+	}
 }
 
-// Execute runs all steps in the scenario
-func (e *Executor) Execute() error {
-	fmt.Printf("\n=== Starting Scenario: %s ===\n", e.scenario.ScenarioName)
-	fmt.Printf("Service: %s\n", e.scenario.ServiceName)
-	fmt.Printf("Description: %s\n\n", e.scenario.ScenarioDescription)
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// sanitizeForPath turns a scenario name into something safe to use as a
+// directory component.
+func sanitizeForPath(name string) string {
+	return nonAlphanumeric.ReplaceAllString(strings.TrimSpace(name), "-")
+}
+
+// Execute runs all steps in the scenario. If the scenario fails partway
+// through, any background processes still registered (traffic generators,
+// fault injectors) are torn down before returning so aborted runs don't
+// leave rogue processes hitting production.
+func (e *Executor) Execute() (err error) {
+	e.logger.Info("scenario started", map[string]interface{}{
+		"scenario":    e.scenario.ScenarioName,
+		"service":     e.scenario.ServiceName,
+		"description": e.scenario.ScenarioDescription,
+	})
+
+	defer func() {
+		e.stopDiscoveryWatchers()
+		if err != nil {
+			e.logger.Info("scenario failed, tearing down background processes", map[string]interface{}{
+				"scenario": e.scenario.ScenarioName,
+			})
+			e.registry.StopAll(defaultTeardownGrace)
+		}
+
+		// Stop scraping and write whatever was collected unconditionally -
+		// a failure partway through is exactly when this data matters most,
+		// and the scrape goroutines must not keep running past Execute.
+		e.observer.Stop()
+		if artifactErr := e.observer.WriteArtifact(e.scenario.ScenarioName); artifactErr != nil {
+			e.logger.Info("failed to write metrics artifact", map[string]interface{}{
+				"scenario": e.scenario.ScenarioName,
+				"error":    artifactErr.Error(),
+			})
+		}
+	}()
 
 	for i, step := range e.scenario.Steps {
-		fmt.Printf("[Step %d/%d] %s\n", i+1, len(e.scenario.Steps), step.Name)
-		fmt.Printf("  Description: %s\n", step.Description)
-		fmt.Printf("  Type: %s\n", step.Type)
+		e.logger.Info("step started", map[string]interface{}{
+			"step_index":  i + 1,
+			"step_count":  len(e.scenario.Steps),
+			"step_name":   step.Name,
+			"description": step.Description,
+			"step_type":   step.Type,
+		})
+
+		e.lastPID = 0
+		e.lastExitCode = -1
+		start := time.Now()
+
+		stepErr := e.executeStep(step, i+1)
+
+		status := "passed"
+		if stepErr != nil {
+			status = "failed"
+		}
+		e.logger.LogStep(StepEvent{
+			Scenario:   e.scenario.ScenarioName,
+			StepIndex:  i + 1,
+			StepType:   step.Type,
+			Status:     status,
+			DurationMs: time.Since(start).Milliseconds(),
+			PID:        e.lastPID,
+			ExitCode:   e.lastExitCode,
+		})
+
+		if stepErr != nil {
+			err = fmt.Errorf("step %d failed: %w", i+1, stepErr)
+			return err
+		}
 
-		if err := e.executeStep(step); err != nil {
-			return fmt.Errorf("step %d failed: %w", i+1, err)
+		for _, name := range getStringSliceParam(step.Params, "stop_after") {
+			e.logger.Info("stopping background process (stop_after)", map[string]interface{}{"process": name})
+			if stopErr := e.registry.Stop(name, defaultTeardownGrace); stopErr != nil {
+				err = stopErr
+				return err
+			}
 		}
-		fmt.Println()
 	}
 
-	fmt.Printf("=== Scenario Completed: %s ===\n\n", e.scenario.ScenarioName)
+	e.logger.Info("scenario completed", map[string]interface{}{"scenario": e.scenario.ScenarioName})
 	return nil
 }
 
+// TeardownAll stops every currently registered background process using the
+// default grace period. It is exported so a signal handler can reap the
+// process tree if the run is interrupted (e.g. Ctrl-C).
+func (e *Executor) TeardownAll() {
+	e.stopDiscoveryWatchers()
+	e.registry.StopAll(defaultTeardownGrace)
+}
+
+// stopDiscoveryWatchers signals every background discovery-watch goroutine
+// spawned by executeFaultStimulationWithDiscovery to exit, so they don't
+// leak past the scenario they belong to.
+func (e *Executor) stopDiscoveryWatchers() {
+	e.discoveryOnce.Do(func() { close(e.discoveryStop) })
+}
+
+// Logs returns the captured structured step events for this run, suitable
+// for embedding in a report's <system-out>.
+func (e *Executor) Logs() string {
+	data, err := os.ReadFile(filepath.Join(e.runDir, "events.log"))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 // executeStep executes a single step based on its type
-func (e *Executor) executeStep(step Step) error {
+func (e *Executor) executeStep(step Step, index int) error {
 	switch step.Type {
 	case StepTypeSetupService:
-		return e.executeSetupService(step)
+		return e.executeSetupService(step, index)
 	case StepTypeSteadyTraffic:
-		return e.executeSteadyTraffic(step)
+		return e.executeSteadyTraffic(step, index)
 	case StepTypeFaultStimulation:
-		return e.executeFaultStimulation(step)
+		return e.executeFaultStimulation(step, index)
+	case StepTypeMetricsCollection:
+		return e.executeMetricsCollection(step)
 	case StepTypeInvestigation:
 		return e.executeInvestigation(step)
 	case StepTypeUserFeedback:
 		return e.executeUserFeedback(step)
+	case StepTypeTeardown:
+		return e.executeTeardown(step)
 	default:
 		return fmt.Errorf("unknown step type: %s", step.Type)
 	}
 }
 
 // executeSetupService runs setup script in blocking mode
-func (e *Executor) executeSetupService(step Step) error {
+func (e *Executor) executeSetupService(step Step, index int) error {
 	scriptPath := getStringParam(step.Params, "script_path")
 	timeout := getIntParam(step.Params, "timeout", 300)
 
-	fmt.Printf("  Executing setup script (blocking, timeout=%ds): %s\n", timeout, scriptPath)
+	e.logger.Info("executing setup script", map[string]interface{}{
+		"script_path": scriptPath,
+		"blocking":    true,
+		"timeout_s":   timeout,
+	})
 
-	return e.runScript(scriptPath, timeout, true, step.Params)
+	return e.runScript(scriptPath, timeout, true, step.Params, index, step.Name)
 }
 
 // executeSteadyTraffic runs traffic script in non-blocking mode
-func (e *Executor) executeSteadyTraffic(step Step) error {
+func (e *Executor) executeSteadyTraffic(step Step, index int) error {
 	scriptPath := getStringParam(step.Params, "script_path")
 
-	fmt.Printf("  Starting traffic script (non-blocking): %s\n", scriptPath)
+	e.logger.Info("starting traffic script", map[string]interface{}{"script_path": scriptPath, "blocking": false})
+	e.observer.Start(step.Name)
 
-	return e.runScript(scriptPath, 0, false, step.Params)
+	return e.runScript(scriptPath, 0, false, step.Params, index, step.Name)
 }
 
-// executeFaultStimulation runs fault script in non-blocking mode
-func (e *Executor) executeFaultStimulation(step Step) error {
+// executeFaultStimulation runs fault script in non-blocking mode. If the
+// step declares a `discovery` block, the script's target is resolved
+// dynamically (e.g. via Consul) instead of being hardcoded in the script.
+func (e *Executor) executeFaultStimulation(step Step, index int) error {
 	scriptPath := getStringParam(step.Params, "script_path")
 	delay := getIntParam(step.Params, "delay", 0)
 
 	if delay > 0 {
-		fmt.Printf("  Waiting %d seconds before fault injection...\n", delay)
+		e.logger.Info("waiting before fault injection", map[string]interface{}{"delay_s": delay})
 		time.Sleep(time.Duration(delay) * time.Second)
 	}
 
-	fmt.Printf("  Injecting fault (non-blocking): %s\n", scriptPath)
+	e.observer.Start(step.Name)
+
+	if discovery, ok := step.Params["discovery"]; ok {
+		return e.executeFaultStimulationWithDiscovery(scriptPath, discovery, step.Params, index, step.Name)
+	}
 
-	return e.runScript(scriptPath, 0, false, step.Params)
+	e.logger.Info("injecting fault", map[string]interface{}{"script_path": scriptPath, "blocking": false})
+	return e.runScript(scriptPath, 0, false, step.Params, index, step.Name)
 }
 
-// executeInvestigation runs Claude investigation in blocking mode
+// executeFaultStimulationWithDiscovery resolves the fault target(s) via the
+// backend named in the discovery block, invokes the fault script once per
+// resolved instance with its host/port appended as script args, and then
+// (unless `random` pinned a single instance) spawns a background watcher
+// that keeps re-polling the backend and injects the fault on any new
+// instance discovered mid-run, so a scenario can adapt if instances come and
+// go while it's running.
+func (e *Executor) executeFaultStimulationWithDiscovery(scriptPath string, discoveryRaw interface{}, params map[string]interface{}, index int, stepName string) error {
+	discovery, ok := discoveryRaw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("discovery block must be a map")
+	}
+
+	service := getStringParam(discovery, "service")
+	if service == "" {
+		return fmt.Errorf("discovery.service is required")
+	}
+	backendName := getStringParam(discovery, "backend")
+	tags := getStringSliceParam(discovery, "tags")
+	healthyOnly := getBoolParam(discovery, "healthy_only", false)
+	random := getBoolParam(discovery, "random", false)
+
+	backend, err := newDiscoveryBackend(backendName)
+	if err != nil {
+		return err
+	}
+
+	instances, lastIndex, err := backend.Discover(service, tags, healthyOnly, 0)
+	if err != nil {
+		return err
+	}
+	if len(instances) == 0 {
+		return fmt.Errorf("no instances discovered for service %q", service)
+	}
+
+	if random {
+		instances = []ServiceInstance{instances[rand.Intn(len(instances))]}
+	}
+
+	for _, instance := range instances {
+		if err := e.injectFaultOnInstance(scriptPath, params, index, stepName, instance); err != nil {
+			return err
+		}
+	}
+
+	// A `random` discovery deliberately pins a single instance for the whole
+	// step, so there's nothing to watch for.
+	if !random {
+		go watchDiscovery(backend, service, tags, healthyOnly, lastIndex, instances, e.discoveryStop, func(fresh []ServiceInstance) {
+			for _, instance := range fresh {
+				if err := e.injectFaultOnInstance(scriptPath, params, index, stepName, instance); err != nil {
+					e.logger.Info("failed to inject fault on newly discovered instance", map[string]interface{}{
+						"host":  instance.Host,
+						"port":  instance.Port,
+						"error": err.Error(),
+					})
+				}
+			}
+		})
+	}
+
+	return nil
+}
+
+// injectFaultOnInstance runs the fault script against a single discovered
+// instance, appending its host/port as script args and registering the
+// resulting process under a name unique to that instance so it can be torn
+// down independently later.
+func (e *Executor) injectFaultOnInstance(scriptPath string, params map[string]interface{}, index int, stepName string, instance ServiceInstance) error {
+	e.logger.Info("injecting fault on discovered instance", map[string]interface{}{
+		"script_path": scriptPath,
+		"host":        instance.Host,
+		"port":        instance.Port,
+		"blocking":    false,
+	})
+
+	instanceParams := cloneParams(params)
+	scriptArgs, _ := instanceParams["script_args"].([]interface{})
+	instanceParams["script_args"] = append(scriptArgs, instance.Host, fmt.Sprintf("%d", instance.Port))
+
+	instanceName := fmt.Sprintf("%s-%s-%d", stepName, instance.Host, instance.Port)
+	return e.runScript(scriptPath, 0, false, instanceParams, index, instanceName)
+}
+
+// executeMetricsCollection starts or stops background Prometheus scraping
+// for the step named in params.for, depending on params.action ("start" or
+// "stop"). Collected samples are buffered under that step's name and are
+// available to later steps (e.g. investigation) and the scenario's metrics
+// artifact.
+func (e *Executor) executeMetricsCollection(step Step) error {
+	action := getStringParam(step.Params, "action")
+	target := getStringParam(step.Params, "for")
+	if target == "" {
+		target = step.Name
+	}
+
+	switch action {
+	case "stop":
+		e.logger.Info("stopping metrics collection", map[string]interface{}{"for": target})
+		e.observer.Stop()
+	default:
+		e.logger.Info("starting metrics collection", map[string]interface{}{"for": target})
+		e.observer.Start(target)
+	}
+
+	return nil
+}
+
+// executeTeardown terminates background processes started by earlier
+// non-blocking steps (steady_traffic, fault_stimulation). By default it
+// stops every still-registered process; `params.processes` narrows it to
+// specific registered names (a step's name, or `<step>-<host>-<port>` for
+// each instance a discovery-driven fault step targeted), and
+// `params.grace_period_seconds` overrides how long
+// each process gets before being SIGKILLed.
+func (e *Executor) executeTeardown(step Step) error {
+	grace := time.Duration(getIntParam(step.Params, "grace_period_seconds", int(defaultTeardownGrace/time.Second))) * time.Second
+	names := getStringSliceParam(step.Params, "processes")
+
+	if len(names) == 0 {
+		e.logger.Info("tearing down all background processes", map[string]interface{}{"grace": grace.String()})
+		e.registry.StopAll(grace)
+		return nil
+	}
+
+	for _, name := range names {
+		e.logger.Info("tearing down process", map[string]interface{}{"process": name, "grace": grace.String()})
+		if err := e.registry.Stop(name, grace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeInvestigation runs an AI investigation in blocking mode against a
+// pluggable Investigator backend (resolved via resolveInvestigatorBackend),
+// passing along every artifact collected so far (script logs, metrics
+// dumps) as evidence.
 func (e *Executor) executeInvestigation(step Step) error {
 	prompt := getStringParam(step.Params, "prompt")
 	timeout := getIntParam(step.Params, "timeout", 600)
 
-	fmt.Printf("  Running AI investigation (blocking, timeout=%ds)...\n", timeout)
+	if metricsFrom := getStringParam(step.Params, "metrics_from"); metricsFrom != "" {
+		prompt = e.augmentPromptWithMetrics(prompt, metricsFrom)
+	}
+
+	backendName := e.resolveInvestigatorBackend(step)
+	investigator, err := newInvestigator(backendName, e.registry)
+	if err != nil {
+		return err
+	}
+
+	// The metrics artifact is otherwise only written once Execute returns;
+	// write what has been collected so far so it is among the evidence.
+	if artifactErr := e.observer.WriteArtifact(e.scenario.ScenarioName); artifactErr != nil {
+		e.logger.Info("failed to write metrics artifact", map[string]interface{}{"error": artifactErr.Error()})
+	}
 
-	cmd := exec.Command("claude", "-p", prompt)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	artifacts := e.collectArtifacts()
 
-	done := make(chan error, 1)
-	go func() {
-		done <- cmd.Run()
-	}()
+	e.logger.Info("running AI investigation", map[string]interface{}{
+		"backend":   backendName,
+		"blocking":  true,
+		"timeout_s": timeout,
+	})
+	return investigator.Investigate(prompt, artifacts, time.Duration(timeout)*time.Second)
+}
 
-	select {
-	case err := <-done:
-		return err
-	case <-time.After(time.Duration(timeout) * time.Second):
-		cmd.Process.Kill()
-		return fmt.Errorf("investigation timed out after %d seconds", timeout)
+// resolveInvestigatorBackend picks the investigation backend to use,
+// preferring (in order) an env-var override, the step's own params.backend,
+// the scenario-level default, and finally the claude CLI.
+func (e *Executor) resolveInvestigatorBackend(step Step) string {
+	if env := os.Getenv("FAULTSIM_INVESTIGATOR_BACKEND"); env != "" {
+		return env
+	}
+	if backend := getStringParam(step.Params, "backend"); backend != "" {
+		return backend
+	}
+	if e.scenario.DefaultInvestigator != "" {
+		return e.scenario.DefaultInvestigator
+	}
+	return "claude"
+}
+
+// collectArtifacts returns every artifact file written so far for this run
+// (step logs, metrics dumps) so an investigation step can attach them as
+// evidence.
+func (e *Executor) collectArtifacts() []string {
+	matches, err := filepath.Glob(filepath.Join(e.runDir, "*"))
+	if err != nil {
+		return nil
+	}
+	return matches
+}
+
+// augmentPromptWithMetrics appends the metric deltas collected during the
+// named step to the investigation prompt, so the model sees recent metric
+// movement rather than just a static description.
+func (e *Executor) augmentPromptWithMetrics(prompt string, stepName string) string {
+	deltas := e.observer.Deltas(stepName)
+	if len(deltas) == 0 {
+		return prompt
+	}
+
+	var sb strings.Builder
+	sb.WriteString(prompt)
+	sb.WriteString(fmt.Sprintf("\n\nMetric deltas observed during step %q:\n", stepName))
+	for metric, delta := range deltas {
+		sb.WriteString(fmt.Sprintf("- %s: %+.2f\n", metric, delta))
 	}
+
+	return sb.String()
 }
 
 // executeUserFeedback asks user for feedback
@@ -143,14 +484,26 @@ func (e *Executor) executeUserFeedback(step Step) error {
 	}
 }
 
-// runScript executes a shell script with optional blocking and timeout
-func (e *Executor) runScript(scriptPath string, timeout int, blocking bool, params map[string]interface{}) error {
+// runScript executes a shell script with optional blocking and timeout,
+// capturing its stdout/stderr into per-step artifact files under the
+// executor's run directory in addition to forwarding them to the console.
+// Non-blocking invocations are started in their own process group and
+// registered under name so they can be torn down later (teardown step,
+// stop_after, or a Ctrl-C signal handler).
+func (e *Executor) runScript(scriptPath string, timeout int, blocking bool, params map[string]interface{}, index int, name string) error {
 	if scriptPath == "" {
 		return fmt.Errorf("script_path is required")
 	}
 
+	// Resolve to an absolute path before changing the command's working
+	// directory below, so a relative script_path still resolves correctly.
+	absScriptPath, err := filepath.Abs(scriptPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve script path %s: %w", scriptPath, err)
+	}
+
 	// Build command with additional parameters
-	args := []string{scriptPath}
+	args := []string{absScriptPath}
 
 	// Add additional script arguments from params
 	if scriptArgs, ok := params["script_args"].([]interface{}); ok {
@@ -160,34 +513,103 @@ func (e *Executor) runScript(scriptPath string, timeout int, blocking bool, para
 	}
 
 	cmd := exec.Command("/bin/bash", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Dir = e.runDir
+
+	outFile, errFile, closeLogs := e.openStepLogFiles(index)
+	cmd.Stdout = io.MultiWriter(os.Stdout, outFile)
+	cmd.Stderr = io.MultiWriter(os.Stderr, errFile)
 
 	if blocking {
-		// Run in blocking mode with timeout
-		done := make(chan error, 1)
+		defer closeLogs()
+
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start script: %w", err)
+		}
+		e.lastPID = cmd.Process.Pid
+
+		// Register the process group even though it's blocking, so a Ctrl-C
+		// arriving mid-step (the single most common interrupt point) reaches
+		// it via TeardownAll instead of orphaning it. Deregister once this
+		// function returns so a later StopAll doesn't re-signal an already
+		// reaped pid.
+		done := make(chan struct{})
+		waitErr := make(chan error, 1)
 		go func() {
-			done <- cmd.Run()
+			waitErr <- cmd.Wait()
+			close(done)
 		}()
+		e.registry.Register(name, cmd.Process.Pid, done)
+		defer e.registry.Deregister(name)
 
+		var err error
 		if timeout > 0 {
 			select {
-			case err := <-done:
-				return err
+			case err = <-waitErr:
 			case <-time.After(time.Duration(timeout) * time.Second):
 				cmd.Process.Kill()
-				return fmt.Errorf("script timed out after %d seconds", timeout)
+				err = fmt.Errorf("script timed out after %d seconds", timeout)
 			}
 		} else {
-			return <-done
+			err = <-waitErr
 		}
-	} else {
-		// Run in non-blocking mode
-		if err := cmd.Start(); err != nil {
-			return fmt.Errorf("failed to start script: %w", err)
+
+		if cmd.ProcessState != nil {
+			e.lastExitCode = cmd.ProcessState.ExitCode()
 		}
-		fmt.Printf("  Script started with PID: %d\n", cmd.Process.Pid)
-		return nil
+		return err
+	}
+
+	// Run in non-blocking mode
+	if err := cmd.Start(); err != nil {
+		closeLogs()
+		return fmt.Errorf("failed to start script: %w", err)
+	}
+	e.logger.Info("script started", map[string]interface{}{"pid": cmd.Process.Pid})
+	e.lastPID = cmd.Process.Pid
+
+	// Reap the child ourselves (instead of polling kill(pid, 0), which still
+	// succeeds against an unreaped zombie) so teardown can detect real exit
+	// immediately rather than blocking for the full grace period every time.
+	done := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		closeLogs()
+		close(done)
+	}()
+
+	e.registry.Register(name, cmd.Process.Pid, done)
+	return nil
+}
+
+// openStepLogFiles creates the step-N.out.log / step-N.err.log artifact
+// files for the given step index, disambiguating repeat calls within the
+// same step (e.g. one fault script invocation per discovered instance).
+func (e *Executor) openStepLogFiles(index int) (*os.File, *os.File, func()) {
+	label := fmt.Sprintf("step-%d", index)
+	call := e.scriptCallCounts[label]
+	e.scriptCallCounts[label] = call + 1
+	if call > 0 {
+		label = fmt.Sprintf("%s.%d", label, call)
+	}
+
+	outPath := filepath.Join(e.runDir, label+".out.log")
+	errPath := filepath.Join(e.runDir, label+".err.log")
+
+	outFile, err := os.Create(outPath)
+	if err != nil {
+		e.logger.Info("failed to create step log file", map[string]interface{}{"path": outPath, "error": err.Error()})
+		outFile, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	}
+	errFile, err := os.Create(errPath)
+	if err != nil {
+		e.logger.Info("failed to create step log file", map[string]interface{}{"path": errPath, "error": err.Error()})
+		errFile, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	}
+
+	return outFile, errFile, func() {
+		outFile.Close()
+		errFile.Close()
 	}
 }
 
@@ -206,7 +628,56 @@ func getIntParam(params map[string]interface{}, key string, defaultVal int) int
 			return v
 		case float64:
 			return int(v)
+		case string:
+			// A param interpolated from ${ENV_VAR}/${scenario.*} stays a
+			// string after YAML decoding even when it carries a number, so
+			// this case must be handled the same as the typed decode in
+			// params.go or interpolated timeouts/delays silently fall back
+			// to defaultVal.
+			if n, err := strconv.Atoi(v); err == nil {
+				return n
+			}
 		}
 	}
 	return defaultVal
 }
+
+// getBoolParam reads a bool-typed param, also accepting a string (the shape
+// an interpolated ${...} value takes after YAML decoding) so interpolation
+// doesn't silently collapse to the zero value.
+func getBoolParam(params map[string]interface{}, key string, defaultVal bool) bool {
+	if val, ok := params[key]; ok {
+		switch v := val.(type) {
+		case bool:
+			return v
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b
+			}
+		}
+	}
+	return defaultVal
+}
+
+func getStringSliceParam(params map[string]interface{}, key string) []string {
+	raw, ok := params[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		values = append(values, fmt.Sprintf("%v", v))
+	}
+	return values
+}
+
+// cloneParams makes a shallow copy of a step's params so per-instance
+// overrides (e.g. discovered script args) don't mutate the original step.
+func cloneParams(params map[string]interface{}) map[string]interface{} {
+	clone := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		clone[k] = v
+	}
+	return clone
+}
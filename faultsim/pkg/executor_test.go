@@ -0,0 +1,93 @@
+package pkg
+
+import "testing"
+
+func TestGetIntParam(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		key    string
+		want   int
+	}{
+		{
+			name:   "int value",
+			params: map[string]interface{}{"timeout": 77},
+			key:    "timeout",
+			want:   77,
+		},
+		{
+			name:   "float64 value (YAML numeric literal)",
+			params: map[string]interface{}{"timeout": float64(77)},
+			key:    "timeout",
+			want:   77,
+		},
+		{
+			name:   "string value (interpolated ${...})",
+			params: map[string]interface{}{"timeout": "77"},
+			key:    "timeout",
+			want:   77,
+		},
+		{
+			name:   "non-numeric string falls back to default",
+			params: map[string]interface{}{"timeout": "not-a-number"},
+			key:    "timeout",
+			want:   300,
+		},
+		{
+			name:   "missing key falls back to default",
+			params: map[string]interface{}{},
+			key:    "timeout",
+			want:   300,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getIntParam(tt.params, tt.key, 300); got != tt.want {
+				t.Errorf("getIntParam() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBoolParam(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		key    string
+		want   bool
+	}{
+		{
+			name:   "bool value",
+			params: map[string]interface{}{"random": true},
+			key:    "random",
+			want:   true,
+		},
+		{
+			name:   "string value (interpolated ${...})",
+			params: map[string]interface{}{"random": "true"},
+			key:    "random",
+			want:   true,
+		},
+		{
+			name:   "non-bool string falls back to default",
+			params: map[string]interface{}{"random": "not-a-bool"},
+			key:    "random",
+			want:   false,
+		},
+		{
+			name:   "missing key falls back to default",
+			params: map[string]interface{}{},
+			key:    "random",
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getBoolParam(tt.params, tt.key, false); got != tt.want {
+				t.Errorf("getBoolParam() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+var interpolationPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// interpolateScenario resolves every `${ENV_VAR}` and `${scenario.<field>}`
+// reference in a scenario's step params up front, so a run doesn't fail
+// halfway through because an env var was unset.
+func interpolateScenario(scenario *Scenario) error {
+	lookup := map[string]string{
+		"scenario.service_name":  scenario.ServiceName,
+		"scenario.service_path":  scenario.ServicePath,
+		"scenario.scenario_name": scenario.ScenarioName,
+	}
+
+	var firstErr error
+	resolve := func(match string) string {
+		key := interpolationPattern.FindStringSubmatch(match)[1]
+
+		if val, ok := lookup[key]; ok {
+			return val
+		}
+		if val, ok := os.LookupEnv(key); ok {
+			return val
+		}
+
+		if firstErr == nil {
+			firstErr = fmt.Errorf("unresolved interpolation ${%s}", key)
+		}
+		return match
+	}
+
+	for i := range scenario.Steps {
+		for key, val := range scenario.Steps[i].Params {
+			scenario.Steps[i].Params[key] = interpolateValue(val, resolve)
+		}
+	}
+
+	return firstErr
+}
+
+// interpolateValue recursively resolves ${...} references in strings nested
+// anywhere inside a step's params (maps, slices, or bare strings).
+func interpolateValue(val interface{}, resolve func(string) string) interface{} {
+	switch v := val.(type) {
+	case string:
+		return interpolationPattern.ReplaceAllStringFunc(v, resolve)
+	case map[string]interface{}:
+		for k, nested := range v {
+			v[k] = interpolateValue(nested, resolve)
+		}
+		return v
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = interpolateValue(nested, resolve)
+		}
+		return v
+	default:
+		return val
+	}
+}
@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"os"
+	"testing"
+)
+
+func TestInterpolateScenarioResolvesScenarioFields(t *testing.T) {
+	scenario := &Scenario{
+		ServiceName:  "beer-service",
+		ServicePath:  "/srv/beer-service",
+		ScenarioName: "redis-failure",
+		Steps: []Step{
+			{
+				Name: "inject-fault",
+				Params: map[string]interface{}{
+					"script_path": "${scenario.service_path}/faults/kill-redis.sh",
+					"label":       "${scenario.scenario_name} against ${scenario.service_name}",
+				},
+			},
+		},
+	}
+
+	if err := interpolateScenario(scenario); err != nil {
+		t.Fatalf("interpolateScenario returned error: %v", err)
+	}
+
+	params := scenario.Steps[0].Params
+	if got, want := params["script_path"], "/srv/beer-service/faults/kill-redis.sh"; got != want {
+		t.Errorf("script_path = %q, want %q", got, want)
+	}
+	if got, want := params["label"], "redis-failure against beer-service"; got != want {
+		t.Errorf("label = %q, want %q", got, want)
+	}
+}
+
+func TestInterpolateScenarioResolvesEnvVar(t *testing.T) {
+	t.Setenv("FAULTSIM_TEST_TIMEOUT", "42")
+
+	scenario := &Scenario{
+		Steps: []Step{
+			{Name: "setup", Params: map[string]interface{}{"timeout": "${FAULTSIM_TEST_TIMEOUT}"}},
+		},
+	}
+
+	if err := interpolateScenario(scenario); err != nil {
+		t.Fatalf("interpolateScenario returned error: %v", err)
+	}
+
+	if got, want := scenario.Steps[0].Params["timeout"], "42"; got != want {
+		t.Errorf("timeout = %q, want %q", got, want)
+	}
+}
+
+func TestInterpolateScenarioUnresolvedVarIsAnError(t *testing.T) {
+	os.Unsetenv("FAULTSIM_DOES_NOT_EXIST")
+
+	scenario := &Scenario{
+		Steps: []Step{
+			{Name: "setup", Params: map[string]interface{}{"script_path": "${FAULTSIM_DOES_NOT_EXIST}"}},
+		},
+	}
+
+	if err := interpolateScenario(scenario); err == nil {
+		t.Fatal("expected an error for an unresolved interpolation, got nil")
+	}
+}
+
+func TestInterpolateScenarioResolvesNestedMapsAndSlices(t *testing.T) {
+	scenario := &Scenario{ServiceName: "beer-service"}
+	scenario.Steps = []Step{
+		{
+			Name: "inject-fault",
+			Params: map[string]interface{}{
+				"discovery": map[string]interface{}{
+					"service": "${scenario.service_name}",
+					"tags":    []interface{}{"${scenario.service_name}-primary", "static-tag"},
+				},
+			},
+		},
+	}
+
+	if err := interpolateScenario(scenario); err != nil {
+		t.Fatalf("interpolateScenario returned error: %v", err)
+	}
+
+	discovery := scenario.Steps[0].Params["discovery"].(map[string]interface{})
+	if got, want := discovery["service"], "beer-service"; got != want {
+		t.Errorf("discovery.service = %q, want %q", got, want)
+	}
+
+	tags := discovery["tags"].([]interface{})
+	if got, want := tags[0], "beer-service-primary"; got != want {
+		t.Errorf("tags[0] = %q, want %q", got, want)
+	}
+	if got, want := tags[1], "static-tag"; got != want {
+		t.Errorf("tags[1] = %q, want %q", got, want)
+	}
+}
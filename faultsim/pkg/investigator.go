@@ -0,0 +1,275 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// investigationProcessName is the name the running investigation backend's
+// subprocess (if any) is registered under, so it can be torn down by
+// TeardownAll like any other background process if interrupted mid-run.
+const investigationProcessName = "investigation"
+
+// Investigator runs an AI-assisted root-cause investigation step, given the
+// step's prompt and the artifact paths collected so far (script logs,
+// metrics dumps) so the model sees real evidence instead of a static prompt.
+type Investigator interface {
+	Investigate(prompt string, artifacts []string, timeout time.Duration) error
+}
+
+// newInvestigator resolves an Investigator by backend name, as declared in
+// an investigation step's `params.backend`, a scenario-level default, or an
+// env-var override. registry is threaded through to backends that shell out
+// to a subprocess, so that process group is torn down by TeardownAll like
+// any other background process if the run is interrupted.
+func newInvestigator(backend string, registry *ProcessRegistry) (Investigator, error) {
+	switch backend {
+	case "", "claude":
+		return &ClaudeCLIInvestigator{registry: registry}, nil
+	case "openai":
+		return &OpenAIInvestigator{}, nil
+	case "ollama":
+		return &OllamaInvestigator{}, nil
+	case "noop", "echo":
+		return &NoopInvestigator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown investigation backend: %s", backend)
+	}
+}
+
+// ClaudeCLIInvestigator shells out to the `claude` CLI, attaching each
+// artifact path via --file.
+type ClaudeCLIInvestigator struct {
+	registry *ProcessRegistry
+}
+
+// Investigate runs the Claude CLI against prompt, attaching artifacts as
+// --file flags so it can read real evidence from the run.
+func (c *ClaudeCLIInvestigator) Investigate(prompt string, artifacts []string, timeout time.Duration) error {
+	args := []string{"-p", prompt}
+	for _, artifact := range artifacts {
+		args = append(args, "--file", artifact)
+	}
+
+	cmd := exec.Command("claude", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	return runWithTimeout(cmd, timeout, c.registry)
+}
+
+// OpenAIInvestigator sends the prompt (plus artifact contents inlined as
+// context) to the OpenAI chat completions API, authenticating with
+// OPENAI_API_KEY.
+type OpenAIInvestigator struct{}
+
+// Investigate posts prompt and artifact contents to OpenAI's chat
+// completions endpoint and prints the model's reply.
+func (o *OpenAIInvestigator) Investigate(prompt string, artifacts []string, timeout time.Duration) error {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+
+	model := os.Getenv("OPENAI_MODEL")
+	if model == "" {
+		model = "gpt-4o"
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model": model,
+		"messages": []map[string]string{
+			{"role": "user", "content": inlineArtifacts(prompt, artifacts)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build OpenAI request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build OpenAI request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("OpenAI request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return printOpenAIResponse(resp)
+}
+
+// openAIChatResponse is the subset of the chat completions response shape
+// needed to extract the assistant's reply.
+type openAIChatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// OllamaInvestigator sends the prompt to a local Ollama server, defaulting
+// to http://localhost:11434 unless OLLAMA_HOST is set.
+type OllamaInvestigator struct{}
+
+// Investigate posts prompt and artifact contents to a local Ollama
+// /api/generate endpoint and prints the model's reply.
+func (o *OllamaInvestigator) Investigate(prompt string, artifacts []string, timeout time.Duration) error {
+	host := os.Getenv("OLLAMA_HOST")
+	if host == "" {
+		host = "http://localhost:11434"
+	}
+	model := os.Getenv("OLLAMA_MODEL")
+	if model == "" {
+		model = "llama3"
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model":  model,
+		"prompt": inlineArtifacts(prompt, artifacts),
+		"stream": false,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build Ollama request: %w", err)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Post(host+"/api/generate", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("Ollama request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return printOllamaResponse(resp)
+}
+
+// ollamaGenerateResponse is the subset of the /api/generate response shape
+// needed to extract the model's reply.
+type ollamaGenerateResponse struct {
+	Response string `json:"response"`
+}
+
+// NoopInvestigator echoes the prompt, with the contents of each readable
+// artifact inlined, instead of calling out to a real backend, for CI
+// environments where no AI backend is configured.
+type NoopInvestigator struct{}
+
+// Investigate prints the fully-assembled prompt and returns nil.
+func (n *NoopInvestigator) Investigate(prompt string, artifacts []string, timeout time.Duration) error {
+	fmt.Println(inlineArtifacts(prompt, artifacts))
+	return nil
+}
+
+// inlineArtifacts appends the contents of each artifact path to the prompt
+// as inline context, for backends with no native file-attachment API.
+func inlineArtifacts(prompt string, artifacts []string) string {
+	if len(artifacts) == 0 {
+		return prompt
+	}
+
+	var sb strings.Builder
+	sb.WriteString(prompt)
+	for _, artifact := range artifacts {
+		data, err := os.ReadFile(artifact)
+		if err != nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n\n--- %s ---\n%s", artifact, string(data)))
+	}
+	return sb.String()
+}
+
+// readInvestigationBody reads an HTTP investigator's response body,
+// surfacing non-2xx responses as errors.
+func readInvestigationBody(resp *http.Response) ([]byte, error) {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read investigation response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("investigation backend returned %d: %s", resp.StatusCode, string(data))
+	}
+
+	return data, nil
+}
+
+// printOpenAIResponse extracts and prints the assistant's reply from an
+// OpenAI chat completions response, rather than the raw JSON envelope.
+func printOpenAIResponse(resp *http.Response) error {
+	data, err := readInvestigationBody(resp)
+	if err != nil {
+		return err
+	}
+
+	var parsed openAIChatResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("failed to parse OpenAI response: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return fmt.Errorf("OpenAI response contained no choices: %s", string(data))
+	}
+
+	fmt.Println(parsed.Choices[0].Message.Content)
+	return nil
+}
+
+// printOllamaResponse extracts and prints the model's reply from an Ollama
+// /api/generate response, rather than the raw JSON envelope.
+func printOllamaResponse(resp *http.Response) error {
+	data, err := readInvestigationBody(resp)
+	if err != nil {
+		return err
+	}
+
+	var parsed ollamaGenerateResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("failed to parse Ollama response: %w", err)
+	}
+
+	fmt.Println(parsed.Response)
+	return nil
+}
+
+// runWithTimeout starts cmd, registering its process group with registry (if
+// non-nil) so it can be torn down by TeardownAll if the run is interrupted,
+// and kills it if it exceeds timeout.
+func runWithTimeout(cmd *exec.Cmd, timeout time.Duration, registry *ProcessRegistry) error {
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start investigation command: %w", err)
+	}
+
+	done := make(chan struct{})
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- cmd.Wait()
+		close(done)
+	}()
+
+	if registry != nil {
+		registry.Register(investigationProcessName, cmd.Process.Pid, done)
+		defer registry.Deregister(investigationProcessName)
+	}
+
+	select {
+	case err := <-waitErr:
+		return err
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		return fmt.Errorf("investigation timed out after %s", timeout)
+	}
+}
@@ -8,18 +8,41 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// LoadScenario loads a scenario from a YAML file
+// LoadScenario loads a scenario from a YAML file, validating it against the
+// embedded schema, resolving ${ENV_VAR} / ${scenario.*} interpolations, and
+// type-checking every step's params (in that order, so an interpolated
+// placeholder has already been resolved to its real value before it's
+// decoded into a typed field) before returning it - so a malformed scenario
+// is rejected at load time instead of halfway through a long-running
+// scenario.
 func LoadScenario(path string) (*Scenario, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read scenario file: %w", err)
 	}
 
+	if err := ValidateScenarioSchema(data); err != nil {
+		return nil, err
+	}
+
 	var scenario Scenario
 	if err := yaml.Unmarshal(data, &scenario); err != nil {
 		return nil, fmt.Errorf("failed to parse scenario YAML: %w", err)
 	}
 
+	// Interpolate before type-checking: a param like `timeout: "${BUILD_TIMEOUT}"`
+	// is still a string at this point, so it must be resolved before decoding
+	// into a typed (e.g. int) field, or the decode fails on the placeholder text.
+	if err := interpolateScenario(&scenario); err != nil {
+		return nil, err
+	}
+
+	for _, step := range scenario.Steps {
+		if err := decodeStepParams(step); err != nil {
+			return nil, err
+		}
+	}
+
 	return &scenario, nil
 }
 
@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"io"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Logger is the structured event sink used by Executor. It is an interface
+// so a scenario run can plug in an alternate logger if the default JSON
+// writer doesn't fit a downstream pipeline.
+type Logger interface {
+	LogStep(event StepEvent)
+	Info(message string, fields map[string]interface{})
+}
+
+// StepEvent is the structured record emitted for every step the Executor
+// runs, one JSON line per step.
+type StepEvent struct {
+	Scenario   string
+	StepIndex  int
+	StepType   string
+	Status     string
+	DurationMs int64
+	PID        int
+	ExitCode   int
+}
+
+// LogrusLogger is the default Logger, emitting one JSON-formatted logrus
+// entry per step event.
+type LogrusLogger struct {
+	log *logrus.Logger
+}
+
+// NewLogrusLogger creates a LogrusLogger writing JSON lines to w.
+func NewLogrusLogger(w io.Writer) *LogrusLogger {
+	log := logrus.New()
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetOutput(w)
+	return &LogrusLogger{log: log}
+}
+
+// LogStep emits a single JSON event describing a completed step.
+func (l *LogrusLogger) LogStep(event StepEvent) {
+	l.log.WithFields(logrus.Fields{
+		"scenario":    event.Scenario,
+		"step_index":  event.StepIndex,
+		"step_type":   event.StepType,
+		"status":      event.Status,
+		"duration_ms": event.DurationMs,
+		"pid":         event.PID,
+		"exit_code":   event.ExitCode,
+	}).Info("step completed")
+}
+
+// Info emits a single JSON-formatted progress event, replacing the ad-hoc
+// fmt.Printf narration Executor used to print directly to the console, so
+// downstream pipelines can consume a scenario's progress without scraping
+// stdout.
+func (l *LogrusLogger) Info(message string, fields map[string]interface{}) {
+	l.log.WithFields(fields).Info(message)
+}
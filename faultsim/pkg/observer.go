@@ -0,0 +1,300 @@
+package pkg
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PrometheusEndpoint describes a metrics endpoint to scrape during a scenario run.
+type PrometheusEndpoint struct {
+	URL            string   `yaml:"url"`
+	ScrapeInterval int      `yaml:"scrape_interval"`
+	Metrics        []string `yaml:"metrics"`
+}
+
+// ObservabilityConfig is the scenario-level configuration for the Observer.
+type ObservabilityConfig struct {
+	Endpoints []PrometheusEndpoint `yaml:"endpoints"`
+}
+
+// MetricSample is a single Prometheus sample scraped from an endpoint.
+type MetricSample struct {
+	Endpoint  string    `json:"endpoint"`
+	Metric    string    `json:"metric"`
+	Value     float64   `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Observer scrapes a configurable list of Prometheus endpoints in the
+// background and buffers the collected samples per step, so later steps
+// (e.g. investigation) can see recent metric movement and a full artifact
+// can be written out once the scenario finishes.
+type Observer struct {
+	endpoints []PrometheusEndpoint
+	runDir    string
+
+	mu      sync.Mutex
+	samples map[string][]MetricSample // keyed by step name
+
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+	running    bool
+	activeStep string
+}
+
+// NewObserver creates an Observer for the given endpoints, writing its
+// artifact to runDir once the scenario completes.
+func NewObserver(endpoints []PrometheusEndpoint, runDir string) *Observer {
+	return &Observer{
+		endpoints: endpoints,
+		runDir:    runDir,
+		samples:   make(map[string][]MetricSample),
+	}
+}
+
+// Start begins scraping every configured endpoint in the background,
+// tagging every sample collected under stepName until Stop is called or
+// Start is called again with a different stepName. Calling Start again with
+// the same stepName is a no-op; calling it with a new one restarts the scrape
+// loops so samples are correctly re-tagged (e.g. steady_traffic handing off
+// to fault_stimulation without an explicit metrics_collection stop step in
+// between). It is a no-op if no endpoints are configured.
+func (o *Observer) Start(stepName string) {
+	if o == nil || len(o.endpoints) == 0 {
+		return
+	}
+	if o.running {
+		if o.activeStep == stepName {
+			return
+		}
+		o.stopActive()
+	}
+
+	o.running = true
+	o.activeStep = stepName
+	o.stopCh = make(chan struct{})
+
+	for _, ep := range o.endpoints {
+		o.wg.Add(1)
+		go o.scrapeLoop(ep, stepName, o.stopCh)
+	}
+}
+
+// Stop halts all in-flight scrape loops started by Start.
+func (o *Observer) Stop() {
+	if o == nil || !o.running {
+		return
+	}
+	o.stopActive()
+}
+
+// stopActive halts the currently running scrape loops. Callers must have
+// already checked o.running.
+func (o *Observer) stopActive() {
+	close(o.stopCh)
+	o.wg.Wait()
+	o.running = false
+}
+
+func (o *Observer) scrapeLoop(ep PrometheusEndpoint, stepName string, stop chan struct{}) {
+	defer o.wg.Done()
+
+	interval := ep.ScrapeInterval
+	if interval <= 0 {
+		interval = 5
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		o.scrapeOnce(ep, stepName)
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *Observer) scrapeOnce(ep PrometheusEndpoint, stepName string) {
+	resp, err := http.Get(ep.URL)
+	if err != nil {
+		fmt.Printf("  [observer] failed to scrape %s: %v\n", ep.URL, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	allowed := make(map[string]bool, len(ep.Metrics))
+	for _, m := range ep.Metrics {
+		allowed[m] = true
+	}
+
+	now := time.Now()
+	var collected []MetricSample
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := parsePrometheusLine(line)
+		if !ok {
+			continue
+		}
+		if len(allowed) > 0 && !allowed[name] {
+			continue
+		}
+		collected = append(collected, MetricSample{
+			Endpoint:  ep.URL,
+			Metric:    name,
+			Value:     value,
+			Timestamp: now,
+		})
+	}
+
+	if len(collected) == 0 {
+		return
+	}
+
+	o.mu.Lock()
+	o.samples[stepName] = append(o.samples[stepName], collected...)
+	o.mu.Unlock()
+}
+
+// parsePrometheusLine parses a single line of the Prometheus text exposition
+// format ("metric_name{labels} value"), discarding labels.
+func parsePrometheusLine(line string) (string, float64, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return "", 0, false
+	}
+
+	name := fields[0]
+	if idx := strings.IndexByte(name, '{'); idx >= 0 {
+		name = name[:idx]
+	}
+
+	value, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return name, value, true
+}
+
+// SamplesFor returns the samples buffered so far for the given step name.
+func (o *Observer) SamplesFor(stepName string) []MetricSample {
+	if o == nil {
+		return nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]MetricSample(nil), o.samples[stepName]...)
+}
+
+// Deltas summarizes, per metric, the change between the first and last
+// buffered sample for stepName -- e.g. to answer "how much did error_rate
+// move during the fault window" when augmenting an investigation prompt.
+func (o *Observer) Deltas(stepName string) map[string]float64 {
+	samples := o.SamplesFor(stepName)
+
+	first := make(map[string]float64)
+	last := make(map[string]float64)
+	for _, s := range samples {
+		if _, ok := first[s.Metric]; !ok {
+			first[s.Metric] = s.Value
+		}
+		last[s.Metric] = s.Value
+	}
+
+	deltas := make(map[string]float64, len(last))
+	for metric, lastVal := range last {
+		deltas[metric] = lastVal - first[metric]
+	}
+	return deltas
+}
+
+// WriteArtifact writes every sample collected so far to a JSON file and a
+// CSV file under the observer's run directory, both named after the
+// scenario.
+func (o *Observer) WriteArtifact(scenarioName string) error {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.samples) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(o.runDir, 0755); err != nil {
+		return fmt.Errorf("failed to create observability artifact dir: %w", err)
+	}
+
+	if err := o.writeJSONArtifact(scenarioName); err != nil {
+		return err
+	}
+	return o.writeCSVArtifact(scenarioName)
+}
+
+// writeJSONArtifact writes the full, step-keyed sample set to a JSON file.
+// Callers must hold o.mu.
+func (o *Observer) writeJSONArtifact(scenarioName string) error {
+	path := filepath.Join(o.runDir, fmt.Sprintf("%s-metrics.json", scenarioName))
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics artifact: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(o.samples)
+}
+
+// writeCSVArtifact flattens every sample into a single step,endpoint,metric,
+// value,timestamp CSV, for loading into a spreadsheet or a SLO-assertion
+// script without needing a JSON parser. Callers must hold o.mu.
+func (o *Observer) writeCSVArtifact(scenarioName string) error {
+	path := filepath.Join(o.runDir, fmt.Sprintf("%s-metrics.csv", scenarioName))
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics artifact: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := w.Write([]string{"step", "endpoint", "metric", "value", "timestamp"}); err != nil {
+		return fmt.Errorf("failed to write metrics CSV header: %w", err)
+	}
+
+	for step, samples := range o.samples {
+		for _, s := range samples {
+			row := []string{
+				step,
+				s.Endpoint,
+				s.Metric,
+				strconv.FormatFloat(s.Value, 'f', -1, 64),
+				s.Timestamp.Format(time.RFC3339),
+			}
+			if err := w.Write(row); err != nil {
+				return fmt.Errorf("failed to write metrics CSV row: %w", err)
+			}
+		}
+	}
+
+	return w.Error()
+}
@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePrometheusLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		wantOK    bool
+		wantName  string
+		wantValue float64
+	}{
+		{
+			name:      "simple metric",
+			line:      "http_requests_total 1024",
+			wantOK:    true,
+			wantName:  "http_requests_total",
+			wantValue: 1024,
+		},
+		{
+			name:      "metric with labels",
+			line:      `http_requests_total{method="GET",status="200"} 512`,
+			wantOK:    true,
+			wantName:  "http_requests_total",
+			wantValue: 512,
+		},
+		{
+			name:      "negative and fractional value",
+			line:      "error_rate -0.5",
+			wantOK:    true,
+			wantName:  "error_rate",
+			wantValue: -0.5,
+		},
+		{
+			name:   "comment line",
+			line:   "# HELP http_requests_total total requests",
+			wantOK: false,
+		},
+		{
+			name:   "blank line",
+			line:   "",
+			wantOK: false,
+		},
+		{
+			name:   "non-numeric value",
+			line:   "http_requests_total not-a-number",
+			wantOK: false,
+		},
+		{
+			name:   "missing value",
+			line:   "http_requests_total",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// parsePrometheusLine itself doesn't filter comments/blanks
+			// (scrapeOnce does that before calling it); it should still
+			// fail gracefully rather than panic if handed one directly.
+			name, value, ok := parsePrometheusLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !tt.wantOK {
+				return
+			}
+			if name != tt.wantName {
+				t.Errorf("name = %q, want %q", name, tt.wantName)
+			}
+			if value != tt.wantValue {
+				t.Errorf("value = %v, want %v", value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestObserverSamplesForReturnsACopy(t *testing.T) {
+	o := NewObserver(nil, t.TempDir())
+	o.samples["fault-step"] = []MetricSample{{Metric: "error_rate", Value: 1}}
+
+	samples := o.SamplesFor("fault-step")
+	samples[0].Value = 999
+
+	if o.samples["fault-step"][0].Value != 1 {
+		t.Fatal("SamplesFor leaked a mutable reference to the observer's internal buffer")
+	}
+}
+
+func TestObserverWriteArtifactWritesJSONAndCSV(t *testing.T) {
+	runDir := t.TempDir()
+	o := NewObserver(nil, runDir)
+	o.samples["fault-step"] = []MetricSample{{Endpoint: "http://localhost:9090/metrics", Metric: "error_rate", Value: 0.5}}
+
+	if err := o.WriteArtifact("my-scenario"); err != nil {
+		t.Fatalf("WriteArtifact() error = %v", err)
+	}
+
+	jsonPath := filepath.Join(runDir, "my-scenario-metrics.json")
+	if _, err := os.Stat(jsonPath); err != nil {
+		t.Errorf("expected JSON artifact at %s: %v", jsonPath, err)
+	}
+
+	csvPath := filepath.Join(runDir, "my-scenario-metrics.csv")
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("expected CSV artifact at %s: %v", csvPath, err)
+	}
+	if got := string(data); got != "step,endpoint,metric,value,timestamp\nfault-step,http://localhost:9090/metrics,error_rate,0.5,"+o.samples["fault-step"][0].Timestamp.Format("2006-01-02T15:04:05Z07:00")+"\n" {
+		t.Errorf("unexpected CSV content:\n%s", got)
+	}
+}
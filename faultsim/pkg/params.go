@@ -0,0 +1,131 @@
+package pkg
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// SetupServiceParams is the typed shape of a setup_service step's params.
+type SetupServiceParams struct {
+	ScriptPath string `mapstructure:"script_path"`
+	Timeout    int    `mapstructure:"timeout"`
+}
+
+// SteadyTrafficParams is the typed shape of a steady_traffic step's params.
+type SteadyTrafficParams struct {
+	ScriptPath string `mapstructure:"script_path"`
+}
+
+// DiscoveryParams is the typed shape of a fault_stimulation step's
+// discovery block.
+type DiscoveryParams struct {
+	Backend     string   `mapstructure:"backend"`
+	Service     string   `mapstructure:"service"`
+	Tags        []string `mapstructure:"tags"`
+	HealthyOnly bool     `mapstructure:"healthy_only"`
+	Random      bool     `mapstructure:"random"`
+}
+
+// FaultStimulationParams is the typed shape of a fault_stimulation step's
+// params.
+type FaultStimulationParams struct {
+	ScriptPath string           `mapstructure:"script_path"`
+	Delay      int              `mapstructure:"delay"`
+	Discovery  *DiscoveryParams `mapstructure:"discovery"`
+	StopAfter  []string         `mapstructure:"stop_after"`
+}
+
+// MetricsCollectionParams is the typed shape of a metrics_collection step's
+// params.
+type MetricsCollectionParams struct {
+	Action string `mapstructure:"action"`
+	For    string `mapstructure:"for"`
+}
+
+// InvestigationParams is the typed shape of an investigation step's params.
+type InvestigationParams struct {
+	Prompt      string `mapstructure:"prompt"`
+	Timeout     int    `mapstructure:"timeout"`
+	Backend     string `mapstructure:"backend"`
+	MetricsFrom string `mapstructure:"metrics_from"`
+}
+
+// UserFeedbackParams is the typed shape of a user_feedback step's params.
+type UserFeedbackParams struct {
+	Question      string `mapstructure:"question"`
+	ExpectedFault string `mapstructure:"expected_fault"`
+}
+
+// TeardownParams is the typed shape of a teardown step's params.
+type TeardownParams struct {
+	Processes          []string `mapstructure:"processes"`
+	GracePeriodSeconds int      `mapstructure:"grace_period_seconds"`
+}
+
+// decodeStepParams type-checks a step's params against the struct shape
+// expected for its step type, so a typo like a missing script_path is
+// caught at load time instead of halfway through a long-running scenario.
+func decodeStepParams(step Step) error {
+	switch step.Type {
+	case StepTypeSetupService:
+		var p SetupServiceParams
+		if err := decodeParamsInto(step, &p); err != nil {
+			return err
+		}
+		return requireField(step, "script_path", p.ScriptPath)
+	case StepTypeSteadyTraffic:
+		var p SteadyTrafficParams
+		if err := decodeParamsInto(step, &p); err != nil {
+			return err
+		}
+		return requireField(step, "script_path", p.ScriptPath)
+	case StepTypeFaultStimulation:
+		var p FaultStimulationParams
+		if err := decodeParamsInto(step, &p); err != nil {
+			return err
+		}
+		return requireField(step, "script_path", p.ScriptPath)
+	case StepTypeMetricsCollection:
+		return decodeParamsInto(step, &MetricsCollectionParams{})
+	case StepTypeInvestigation:
+		var p InvestigationParams
+		if err := decodeParamsInto(step, &p); err != nil {
+			return err
+		}
+		return requireField(step, "prompt", p.Prompt)
+	case StepTypeUserFeedback:
+		var p UserFeedbackParams
+		if err := decodeParamsInto(step, &p); err != nil {
+			return err
+		}
+		return requireField(step, "question", p.Question)
+	case StepTypeTeardown:
+		return decodeParamsInto(step, &TeardownParams{})
+	default:
+		return fmt.Errorf("unknown step type: %s", step.Type)
+	}
+}
+
+// decodeParamsInto type-checks step.Params against target's struct shape.
+func decodeParamsInto(step Step, target interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           target,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build params decoder: %w", err)
+	}
+
+	if err := decoder.Decode(step.Params); err != nil {
+		return fmt.Errorf("invalid params for step %q (%s): %w", step.Name, step.Type, err)
+	}
+	return nil
+}
+
+func requireField(step Step, field, value string) error {
+	if value == "" {
+		return fmt.Errorf("step %q (%s): %s is required", step.Name, step.Type, field)
+	}
+	return nil
+}
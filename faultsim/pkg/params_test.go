@@ -0,0 +1,83 @@
+package pkg
+
+import "testing"
+
+func TestDecodeStepParamsRequiredFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    Step
+		wantErr bool
+	}{
+		{
+			name:    "setup_service missing script_path",
+			step:    Step{Name: "setup", Type: StepTypeSetupService, Params: map[string]interface{}{}},
+			wantErr: true,
+		},
+		{
+			name:    "setup_service with script_path",
+			step:    Step{Name: "setup", Type: StepTypeSetupService, Params: map[string]interface{}{"script_path": "./setup.sh"}},
+			wantErr: false,
+		},
+		{
+			name:    "fault_stimulation missing script_path",
+			step:    Step{Name: "fault", Type: StepTypeFaultStimulation, Params: map[string]interface{}{"delay": 5}},
+			wantErr: true,
+		},
+		{
+			name:    "investigation missing prompt",
+			step:    Step{Name: "investigate", Type: StepTypeInvestigation, Params: map[string]interface{}{"timeout": 60}},
+			wantErr: true,
+		},
+		{
+			name:    "investigation with prompt",
+			step:    Step{Name: "investigate", Type: StepTypeInvestigation, Params: map[string]interface{}{"prompt": "what broke?"}},
+			wantErr: false,
+		},
+		{
+			name:    "user_feedback missing question",
+			step:    Step{Name: "confirm", Type: StepTypeUserFeedback, Params: map[string]interface{}{"expected_fault": "redis down"}},
+			wantErr: true,
+		},
+		{
+			name:    "teardown has no required fields",
+			step:    Step{Name: "teardown", Type: StepTypeTeardown, Params: map[string]interface{}{}},
+			wantErr: false,
+		},
+		{
+			name:    "unknown step type",
+			step:    Step{Name: "mystery", Type: "not_a_real_type", Params: map[string]interface{}{}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decodeStepParams(tt.step)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeStepParamsDecodesNestedDiscoveryBlock(t *testing.T) {
+	step := Step{
+		Name: "fault",
+		Type: StepTypeFaultStimulation,
+		Params: map[string]interface{}{
+			"script_path": "./kill.sh",
+			"discovery": map[string]interface{}{
+				"service":      "redis",
+				"tags":         []interface{}{"primary"},
+				"healthy_only": true,
+			},
+		},
+	}
+
+	if err := decodeStepParams(step); err != nil {
+		t.Fatalf("decodeStepParams returned error: %v", err)
+	}
+}
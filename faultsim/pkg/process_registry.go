@@ -0,0 +1,109 @@
+package pkg
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// defaultTeardownGrace is how long a registered process gets to exit
+// cleanly after SIGTERM before ProcessRegistry escalates to SIGKILL.
+const defaultTeardownGrace = 5 * time.Second
+
+// processEntry is a registered non-blocking process: its process group
+// leader pid, and a channel that closes once the process has actually been
+// reaped (its cmd.Wait() has returned).
+type processEntry struct {
+	pid  int
+	done <-chan struct{}
+}
+
+// ProcessRegistry tracks every non-blocking process an Executor has
+// spawned, so background traffic generators and fault injectors can be
+// stopped explicitly (via a teardown step) or reaped automatically when a
+// scenario fails or is interrupted.
+type ProcessRegistry struct {
+	mu        sync.Mutex
+	processes map[string]processEntry // registered name -> process entry
+}
+
+// NewProcessRegistry creates an empty ProcessRegistry.
+func NewProcessRegistry() *ProcessRegistry {
+	return &ProcessRegistry{processes: make(map[string]processEntry)}
+}
+
+// Register records a running process under name (the step name it was
+// started from, `<step>-<host>-<port>` for each instance a discovery-driven
+// fault step targets, or "investigation" for an investigation backend's
+// subprocess) so it can be torn down later. done must close
+// once the process has actually been reaped (e.g. when the caller's
+// cmd.Wait() goroutine returns), so Stop/StopAll can detect real exit
+// instead of polling a pid that may already be an unreaped zombie.
+func (r *ProcessRegistry) Register(name string, pid int, done <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processes[name] = processEntry{pid: pid, done: done}
+}
+
+// Deregister removes name from the registry without signaling it, for a
+// process that has already exited on its own (e.g. a blocking step that ran
+// to completion) so a later StopAll doesn't try to tear down a pid that's
+// long gone.
+func (r *ProcessRegistry) Deregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.processes, name)
+}
+
+// Stop gracefully terminates the process registered under name: SIGTERM to
+// its process group, escalating to SIGKILL if it hasn't exited after grace.
+func (r *ProcessRegistry) Stop(name string, grace time.Duration) error {
+	r.mu.Lock()
+	entry, ok := r.processes[name]
+	delete(r.processes, name)
+	r.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("no registered process named %q", name)
+	}
+
+	return terminateProcessGroup(entry.pid, entry.done, grace)
+}
+
+// StopAll tears down every still-registered process. Used on scenario
+// failure or interruption so aborted runs don't leave rogue processes
+// hitting production.
+func (r *ProcessRegistry) StopAll(grace time.Duration) {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.processes))
+	for name := range r.processes {
+		names = append(names, name)
+	}
+	r.mu.Unlock()
+
+	for _, name := range names {
+		if err := r.Stop(name, grace); err != nil {
+			fmt.Printf("  Warning: failed to stop process %q: %v\n", name, err)
+		}
+	}
+}
+
+// terminateProcessGroup sends SIGTERM to the process group led by pid,
+// waiting up to grace for done to close (i.e. for the process to actually
+// be reaped) before escalating to SIGKILL.
+func terminateProcessGroup(pid int, done <-chan struct{}, grace time.Duration) error {
+	if err := syscall.Kill(-pid, syscall.SIGTERM); err != nil {
+		if err == syscall.ESRCH {
+			return nil
+		}
+		return fmt.Errorf("failed to SIGTERM process group %d: %w", pid, err)
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(grace):
+		return syscall.Kill(-pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// startTestProcessGroup starts a real, short-lived process group leader
+// (mirroring how runScript starts non-blocking scripts) and reaps it the
+// same way runScript does, returning its pid and a done channel that closes
+// once it's been reaped.
+func startTestProcessGroup(t *testing.T) (int, <-chan struct{}) {
+	t.Helper()
+
+	cmd := exec.Command("sleep", "30")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start test process: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(done)
+	}()
+
+	return cmd.Process.Pid, done
+}
+
+func TestProcessRegistryStopReapsQuicklyOnSIGTERM(t *testing.T) {
+	registry := NewProcessRegistry()
+	pid, done := startTestProcessGroup(t)
+	registry.Register("traffic", pid, done)
+
+	start := time.Now()
+	if err := registry.Stop("traffic", 5*time.Second); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Stop took %s; expected to return as soon as the process exited, well under the 5s grace period", elapsed)
+	}
+}
+
+func TestProcessRegistryStopUnknownName(t *testing.T) {
+	registry := NewProcessRegistry()
+	if err := registry.Stop("nonexistent", time.Second); err == nil {
+		t.Fatal("expected an error stopping an unregistered process, got nil")
+	}
+}
+
+func TestProcessRegistryStopRemovesEntrySoItCannotBeStoppedTwice(t *testing.T) {
+	registry := NewProcessRegistry()
+	pid, done := startTestProcessGroup(t)
+	registry.Register("traffic", pid, done)
+
+	if err := registry.Stop("traffic", 5*time.Second); err != nil {
+		t.Fatalf("first Stop returned error: %v", err)
+	}
+	if err := registry.Stop("traffic", 5*time.Second); err == nil {
+		t.Fatal("expected the second Stop of the same name to error, got nil")
+	}
+}
+
+func TestProcessRegistryStopAllStopsEveryRegisteredProcess(t *testing.T) {
+	registry := NewProcessRegistry()
+
+	pid1, done1 := startTestProcessGroup(t)
+	pid2, done2 := startTestProcessGroup(t)
+	registry.Register("traffic", pid1, done1)
+	registry.Register("fault", pid2, done2)
+
+	registry.StopAll(5 * time.Second)
+
+	select {
+	case <-done1:
+	case <-time.After(2 * time.Second):
+		t.Fatal("first process was not reaped by StopAll")
+	}
+	select {
+	case <-done2:
+	case <-time.After(2 * time.Second):
+		t.Fatal("second process was not reaped by StopAll")
+	}
+}
@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ScenarioResult captures the outcome of running a single scenario, used to
+// build the console summary and the optional JUnit report.
+type ScenarioResult struct {
+	Name      string
+	Passed    bool
+	Error     error
+	Duration  time.Duration
+	LogOutput string
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes a JUnit-format XML report for the given scenario
+// results to path, one <testcase> per scenario with its captured run logs
+// embedded in <system-out>, so CI systems (GitHub Actions, Jenkins) can
+// render pass/fail without scraping console output.
+func WriteJUnitReport(path string, results []ScenarioResult) error {
+	suite := junitTestSuite{
+		Name:  "faultsim",
+		Tests: len(results),
+	}
+
+	for _, r := range results {
+		tc := junitTestCase{
+			Name:      r.Name,
+			ClassName: "faultsim.scenario",
+			Time:      r.Duration.Seconds(),
+			SystemOut: r.LogOutput,
+		}
+
+		if !r.Passed {
+			suite.Failures++
+			message := ""
+			if r.Error != nil {
+				message = r.Error.Error()
+			}
+			tc.Failure = &junitFailure{Message: message, Content: message}
+		}
+
+		suite.Time += r.Duration.Seconds()
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+
+	output := append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, output, 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package pkg
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteJUnitReport(t *testing.T) {
+	results := []ScenarioResult{
+		{
+			Name:      "redis-failover",
+			Passed:    true,
+			Duration:  2 * time.Second,
+			LogOutput: "step 1 passed",
+		},
+		{
+			Name:      "kafka-partition-loss",
+			Passed:    false,
+			Error:     os.ErrDeadlineExceeded,
+			Duration:  3 * time.Second,
+			LogOutput: "step 1 failed",
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := WriteJUnitReport(path, results); err != nil {
+		t.Fatalf("WriteJUnitReport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	var suite junitTestSuite
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		t.Fatalf("failed to parse report XML: %v", err)
+	}
+
+	if suite.Name != "faultsim" {
+		t.Errorf("suite.Name = %q, want %q", suite.Name, "faultsim")
+	}
+	if suite.Tests != len(results) {
+		t.Errorf("suite.Tests = %d, want %d", suite.Tests, len(results))
+	}
+	if suite.Failures != 1 {
+		t.Errorf("suite.Failures = %d, want 1", suite.Failures)
+	}
+	wantTime := 5.0
+	if suite.Time != wantTime {
+		t.Errorf("suite.Time = %v, want %v", suite.Time, wantTime)
+	}
+	if len(suite.TestCases) != len(results) {
+		t.Fatalf("len(suite.TestCases) = %d, want %d", len(suite.TestCases), len(results))
+	}
+
+	passedCase := suite.TestCases[0]
+	if passedCase.Name != "redis-failover" {
+		t.Errorf("TestCases[0].Name = %q, want %q", passedCase.Name, "redis-failover")
+	}
+	if passedCase.Failure != nil {
+		t.Errorf("TestCases[0].Failure = %+v, want nil for a passed scenario", passedCase.Failure)
+	}
+
+	failedCase := suite.TestCases[1]
+	if failedCase.Failure == nil {
+		t.Fatal("TestCases[1].Failure = nil, want populated for a failed scenario")
+	}
+	if failedCase.Failure.Message != os.ErrDeadlineExceeded.Error() {
+		t.Errorf("TestCases[1].Failure.Message = %q, want %q", failedCase.Failure.Message, os.ErrDeadlineExceeded.Error())
+	}
+}
+
+func TestWriteJUnitReportFailsOnUnwritablePath(t *testing.T) {
+	err := WriteJUnitReport(filepath.Join(t.TempDir(), "missing-dir", "junit.xml"), nil)
+	if err == nil {
+		t.Fatal("expected an error writing to a nonexistent directory, got nil")
+	}
+}
@@ -0,0 +1,78 @@
+package pkg
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+// newTestExecutor builds a minimal Executor suitable for exercising
+// runScript directly, without touching a real "runs/" directory.
+func newTestExecutor(t *testing.T) *Executor {
+	t.Helper()
+	return &Executor{
+		observer:         NewObserver(nil, t.TempDir()),
+		logger:           NewLogrusLogger(io.Discard),
+		registry:         NewProcessRegistry(),
+		runDir:           t.TempDir(),
+		scriptCallCounts: make(map[string]int),
+	}
+}
+
+func TestRunScriptBlockingRegistersWithProcessRegistryWhileRunning(t *testing.T) {
+	e := newTestExecutor(t)
+
+	script := writeTestScript(t, "sleep 0.2")
+
+	done := make(chan error, 1)
+	go func() { done <- e.runScript(script, 0, true, nil, 1, "setup") }()
+
+	// Poll briefly for the registration to land instead of sleeping a fixed
+	// amount, so the test isn't flaky under load.
+	deadline := time.After(time.Second)
+	for {
+		e.registry.mu.Lock()
+		_, registered := e.registry.processes["setup"]
+		e.registry.mu.Unlock()
+		if registered {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("blocking runScript never registered its process with the registry")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("runScript returned error: %v", err)
+	}
+}
+
+func TestRunScriptBlockingDeregistersOnCompletion(t *testing.T) {
+	e := newTestExecutor(t)
+
+	script := writeTestScript(t, "true")
+	if err := e.runScript(script, 0, true, nil, 1, "setup"); err != nil {
+		t.Fatalf("runScript returned error: %v", err)
+	}
+
+	e.registry.mu.Lock()
+	_, stillRegistered := e.registry.processes["setup"]
+	e.registry.mu.Unlock()
+	if stillRegistered {
+		t.Fatal("expected the completed blocking process to have been deregistered")
+	}
+}
+
+// writeTestScript writes body as an executable-by-bash script and returns
+// its path.
+func writeTestScript(t *testing.T, body string) string {
+	t.Helper()
+	path := t.TempDir() + "/script.sh"
+	if err := os.WriteFile(path, []byte("#!/bin/bash\n"+body+"\n"), 0755); err != nil {
+		t.Fatalf("failed to write test script: %v", err)
+	}
+	return path
+}
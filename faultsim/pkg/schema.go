@@ -0,0 +1,61 @@
+package pkg
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed schema/scenario.schema.json
+var scenarioSchemaJSON []byte
+
+// ValidateScenarioSchema validates raw scenario YAML against the embedded
+// JSON Schema, catching structural errors (a missing scenario_name, an
+// unknown step type) before any script executes.
+func ValidateScenarioSchema(raw []byte) error {
+	var generic interface{}
+	if err := yaml.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("failed to parse scenario YAML: %w", err)
+	}
+
+	// Round-trip through encoding/json so map keys and numeric types match
+	// what the schema validator expects (YAML produces ints, JSON floats).
+	jsonBytes, err := json.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("failed to convert scenario to JSON for validation: %w", err)
+	}
+
+	var jsonGeneric interface{}
+	if err := json.Unmarshal(jsonBytes, &jsonGeneric); err != nil {
+		return fmt.Errorf("failed to re-parse scenario JSON for validation: %w", err)
+	}
+
+	schema, err := compileScenarioSchema()
+	if err != nil {
+		return err
+	}
+
+	if err := schema.Validate(jsonGeneric); err != nil {
+		return fmt.Errorf("scenario failed schema validation: %w", err)
+	}
+
+	return nil
+}
+
+func compileScenarioSchema() (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("scenario.schema.json", bytes.NewReader(scenarioSchemaJSON)); err != nil {
+		return nil, fmt.Errorf("failed to load embedded scenario schema: %w", err)
+	}
+
+	schema, err := compiler.Compile("scenario.schema.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile embedded scenario schema: %w", err)
+	}
+
+	return schema, nil
+}
@@ -2,11 +2,13 @@ package pkg
 
 // Scenario represents a fault simulation test scenario
 type Scenario struct {
-	ServiceName        string `yaml:"service_name"`
-	ServicePath        string `yaml:"service_path"`
-	ScenarioName       string `yaml:"scenario_name"`
-	ScenarioDescription string `yaml:"scenario_description"`
-	Steps              []Step `yaml:"steps"`
+	ServiceName         string               `yaml:"service_name"`
+	ServicePath         string               `yaml:"service_path"`
+	ScenarioName        string               `yaml:"scenario_name"`
+	ScenarioDescription string               `yaml:"scenario_description"`
+	Steps               []Step               `yaml:"steps"`
+	Observability       *ObservabilityConfig `yaml:"observability"`
+	DefaultInvestigator string               `yaml:"default_investigator"`
 }
 
 // Step represents a single step in a fault simulation scenario
@@ -19,9 +21,11 @@ type Step struct {
 
 // StepType constants
 const (
-	StepTypeSetupService     = "setup_service"
-	StepTypeSteadyTraffic    = "steady_traffic"
-	StepTypeFaultStimulation = "fault_stimulation"
-	StepTypeInvestigation    = "investigation"
-	StepTypeUserFeedback     = "user_feedback"
+	StepTypeSetupService      = "setup_service"
+	StepTypeSteadyTraffic     = "steady_traffic"
+	StepTypeFaultStimulation  = "fault_stimulation"
+	StepTypeMetricsCollection = "metrics_collection"
+	StepTypeInvestigation     = "investigation"
+	StepTypeUserFeedback      = "user_feedback"
+	StepTypeTeardown          = "teardown"
 )